@@ -0,0 +1,129 @@
+package packngo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind classifies an ErrorResponse by what went wrong, inferred from the
+// HTTP status code alone (the response body is not consulted) so callers can
+// branch without string-matching Errors.
+type ErrorKind string
+
+const (
+	ErrAuth        ErrorKind = "auth"
+	ErrNotFound    ErrorKind = "not_found"
+	ErrRateLimited ErrorKind = "rate_limited"
+	ErrValidation  ErrorKind = "validation"
+	ErrServer      ErrorKind = "server"
+	ErrNetwork     ErrorKind = "network"
+)
+
+// ErrorResponse reports a non-2xx response from the Packet API, or a
+// transport-level failure that never got a response (Kind == ErrNetwork, in
+// which case Response is nil). Errors holds the field-level validation
+// messages Packet returns as a JSON array; Code is an optional
+// machine-readable error code.
+type ErrorResponse struct {
+	Response *http.Response
+
+	Errors []string `json:"errors"`
+	Code   string   `json:"code"`
+	Kind   ErrorKind
+
+	// cause is the underlying transport error for Kind == ErrNetwork. Unwrap
+	// exposes it so errors.Is/As see through to it.
+	cause error
+}
+
+func (r *ErrorResponse) Error() string {
+	if r.Response == nil {
+		return fmt.Sprintf("packngo: request failed: %v", r.cause)
+	}
+	return fmt.Sprintf("%v %v: %d %v",
+		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, strings.Join(r.Errors, "; "))
+}
+
+// Unwrap exposes the underlying transport error for Kind == ErrNetwork, so
+// errors.Is/As(err, ...) can match against it.
+func (r *ErrorResponse) Unwrap() error {
+	return r.cause
+}
+
+// newNetworkErrorResponse wraps a transport-level failure (one that never
+// produced an HTTP response) as an *ErrorResponse with Kind == ErrNetwork, so
+// IsNotFound/IsRateLimited/IsAuth and errors.As work uniformly across both
+// response and transport errors.
+func newNetworkErrorResponse(err error) *ErrorResponse {
+	return &ErrorResponse{Kind: ErrNetwork, Errors: []string{err.Error()}, cause: err}
+}
+
+// errorKind infers an ErrorKind from an HTTP status code.
+func errorKind(statusCode int) ErrorKind {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuth
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	default:
+		if statusCode >= 500 {
+			return ErrServer
+		}
+		return ErrValidation
+	}
+}
+
+// IsNotFound reports whether err is an ErrorResponse for a 404.
+func IsNotFound(err error) bool {
+	var er *ErrorResponse
+	return errors.As(err, &er) && er.Kind == ErrNotFound
+}
+
+// IsRateLimited reports whether err is an ErrorResponse for a 429.
+func IsRateLimited(err error) bool {
+	var er *ErrorResponse
+	return errors.As(err, &er) && er.Kind == ErrRateLimited
+}
+
+// IsAuth reports whether err is an ErrorResponse for a 401/403.
+func IsAuth(err error) bool {
+	var er *ErrorResponse
+	return errors.As(err, &er) && er.Kind == ErrAuth
+}
+
+// CheckResponse returns an *ErrorResponse built from r's status and body when
+// r is not a 2xx, or a wrapped read error if that body cannot be read. For a
+// non-2xx response it reads and closes r.Body itself, so the underlying
+// connection can be reused regardless of how the caller handles the returned
+// error; a 2xx response's body is left open for the caller.
+func CheckResponse(r *http.Response) error {
+	// return if http status code is within 200 range
+	if c := r.StatusCode; c >= 200 && c <= 299 {
+		return nil
+	}
+
+	// fully drain and close the body here, rather than leaving it to the
+	// caller's deferred Close, so the connection is reusable even if the
+	// caller never reads the returned error's body.
+	defer r.Body.Close()
+
+	errorResponse := &ErrorResponse{Response: r, Kind: errorKind(r.StatusCode)}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("packngo: reading error response body: %w", err)
+	}
+	// if the response has a body, populate Errors/Code from it
+	if len(data) > 0 {
+		json.Unmarshal(data, errorResponse)
+	}
+
+	return errorResponse
+}
@@ -0,0 +1,106 @@
+package packngo
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newTestResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request: &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{Path: "/test"},
+		},
+	}
+}
+
+func TestCheckResponseSuccess(t *testing.T) {
+	if err := CheckResponse(newTestResponse(http.StatusOK, "")); err != nil {
+		t.Fatalf("expected no error for 200, got %v", err)
+	}
+}
+
+func TestCheckResponseKindAndHelpers(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		isNotFound bool
+		isRateLtd  bool
+		isAuth     bool
+	}{
+		{"404", http.StatusNotFound, true, false, false},
+		{"429", http.StatusTooManyRequests, false, true, false},
+		{"401", http.StatusUnauthorized, false, false, true},
+		{"403", http.StatusForbidden, false, false, true},
+		{"500", http.StatusInternalServerError, false, false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckResponse(newTestResponse(tc.statusCode, `{"errors":["boom"],"code":"E1"}`))
+			if err == nil {
+				t.Fatal("expected an error for a non-2xx response")
+			}
+
+			if got := IsNotFound(err); got != tc.isNotFound {
+				t.Errorf("IsNotFound = %v, want %v", got, tc.isNotFound)
+			}
+			if got := IsRateLimited(err); got != tc.isRateLtd {
+				t.Errorf("IsRateLimited = %v, want %v", got, tc.isRateLtd)
+			}
+			if got := IsAuth(err); got != tc.isAuth {
+				t.Errorf("IsAuth = %v, want %v", got, tc.isAuth)
+			}
+
+			var er *ErrorResponse
+			if !errors.As(err, &er) {
+				t.Fatal("expected err to be an *ErrorResponse")
+			}
+			if len(er.Errors) != 1 || er.Errors[0] != "boom" {
+				t.Errorf("Errors = %v, want [boom]", er.Errors)
+			}
+			if er.Code != "E1" {
+				t.Errorf("Code = %q, want E1", er.Code)
+			}
+		})
+	}
+}
+
+func TestDoNetworkErrorIsErrNetwork(t *testing.T) {
+	c := NewClient("consumer-secret", "api-secret")
+
+	req, err := c.NewRequestWithContext(context.Background(), http.MethodGet, "http://127.0.0.1:0/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, doErr := c.Do(req, nil)
+	if doErr == nil {
+		t.Fatal("expected an error dialing an invalid address")
+	}
+
+	if IsNotFound(doErr) || IsRateLimited(doErr) || IsAuth(doErr) {
+		t.Errorf("a network failure must not report as not-found/rate-limited/auth")
+	}
+
+	var er *ErrorResponse
+	if !errors.As(doErr, &er) {
+		t.Fatal("expected doErr to be an *ErrorResponse")
+	}
+	if er.Kind != ErrNetwork {
+		t.Errorf("Kind = %q, want %q", er.Kind, ErrNetwork)
+	}
+	if er.Response != nil {
+		t.Errorf("Response = %v, want nil for a transport-level failure", er.Response)
+	}
+	if !errors.Is(doErr, doErr) {
+		t.Fatal("errors.Is(doErr, doErr) must hold")
+	}
+}
@@ -0,0 +1,56 @@
+package packngo
+
+import "net/http"
+
+// Logger is a minimal logging interface compatible with common logging
+// packages (e.g. logrus, zap's SugaredLogger), used to trace API calls made
+// by a Client.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+// redactedHeaders lists request headers whose values must never reach a
+// Logger or an AfterResponse hook verbatim. BeforeRequest is the exception:
+// it receives the real, unredacted request.
+var redactedHeaders = []string{"X-Auth-Token", "X-Consumer-Token"}
+
+// redactHeaders returns a copy of h with sensitive values replaced, safe to
+// hand to a Logger.
+func redactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+// redactedRequest returns a shallow copy of req with redactHeaders applied to
+// its Header, safe to hand to an AfterResponse hook. req itself is left
+// untouched, since Do may still need its real headers to resend the request
+// on retry.
+func redactedRequest(req *http.Request) *http.Request {
+	if req == nil {
+		return nil
+	}
+	redacted := *req
+	redacted.Header = redactHeaders(req.Header)
+	return &redacted
+}
+
+// redactedForHook returns a copy of resp with redactedRequest applied to its
+// embedded Request, safe to hand to an AfterResponse hook. resp itself is
+// left untouched.
+func redactedForHook(resp *Response) *Response {
+	if resp == nil || resp.Response == nil {
+		return resp
+	}
+	httpResp := *resp.Response
+	httpResp.Request = redactedRequest(httpResp.Request)
+	redacted := *resp
+	redacted.Response = &httpResp
+	return &redacted
+}
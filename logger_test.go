@@ -0,0 +1,111 @@
+package packngo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBeforeRequestSeesRealRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient("consumer-secret", "api-secret")
+
+	var sawBefore *http.Request
+	c.BeforeRequest = func(req *http.Request) {
+		sawBefore = req
+		req.Header.Set("Traceparent", "00-trace-id-span-id-01")
+	}
+
+	req, err := c.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if sawBefore == nil {
+		t.Fatal("BeforeRequest hook was not called")
+	}
+	if sawBefore != req {
+		t.Error("BeforeRequest was handed a copy, not the real *http.Request")
+	}
+	// BeforeRequest sees the real, unredacted request: it may need the
+	// credentials to decide what to do, and mutations (e.g. adding a trace
+	// header) must reach the request actually sent.
+	if v := sawBefore.Header.Get("X-Auth-Token"); v != "api-secret" {
+		t.Errorf("BeforeRequest: X-Auth-Token = %q, want api-secret", v)
+	}
+	if v := req.Header.Get("Traceparent"); v != "00-trace-id-span-id-01" {
+		t.Errorf("req.Header[Traceparent] = %q, want header injected by BeforeRequest to survive", v)
+	}
+}
+
+func TestAfterResponseSeesRedactedHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient("consumer-secret", "api-secret")
+
+	var sawAfter *http.Request
+	c.AfterResponse = func(resp *Response, err error) {
+		if resp != nil && resp.Response != nil {
+			sawAfter = resp.Response.Request
+		}
+	}
+
+	req, err := c.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if sawAfter == nil {
+		t.Fatal("AfterResponse hook was not called")
+	}
+	if v := sawAfter.Header.Get("X-Auth-Token"); v != "REDACTED" {
+		t.Errorf("AfterResponse: X-Auth-Token = %q, want REDACTED", v)
+	}
+	if v := sawAfter.Header.Get("X-Consumer-Token"); v != "REDACTED" {
+		t.Errorf("AfterResponse: X-Consumer-Token = %q, want REDACTED", v)
+	}
+
+	// The real request must still carry its credentials, since Do may need
+	// to resend it on retry.
+	if v := req.Header.Get("X-Auth-Token"); v != "api-secret" {
+		t.Errorf("req.Header[X-Auth-Token] was mutated, got %q", v)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Auth-Token", "api-secret")
+	h.Set("X-Consumer-Token", "consumer-secret")
+	h.Set("Accept", "application/json")
+
+	redacted := redactHeaders(h)
+
+	if v := redacted.Get("X-Auth-Token"); v != "REDACTED" {
+		t.Errorf("X-Auth-Token = %q, want REDACTED", v)
+	}
+	if v := redacted.Get("X-Consumer-Token"); v != "REDACTED" {
+		t.Errorf("X-Consumer-Token = %q, want REDACTED", v)
+	}
+	if v := redacted.Get("Accept"); v != "application/json" {
+		t.Errorf("Accept = %q, want untouched value application/json", v)
+	}
+
+	// The original must be left untouched.
+	if v := h.Get("X-Auth-Token"); v != "api-secret" {
+		t.Errorf("original header was mutated: X-Auth-Token = %q", v)
+	}
+}
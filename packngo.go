@@ -2,15 +2,13 @@ package packngo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
-	"crypto/tls"
 )
 
 const (
@@ -55,15 +53,6 @@ func (r *Response) populateRate() {
 	}
 }
 
-type ErrorResponse struct {
-	Response *http.Response
-	Message string
-}
-func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v",
-		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
-}
-
 // the base API Client
 type Client struct {
 	client *http.Client
@@ -74,8 +63,36 @@ type Client struct {
 	ConsumerToken string
 	ApiKey string
 
+	// RateLimit holds the most recently observed rate-limit state and is
+	// also used to pace retry backoff (see retryWait). It's shared by every
+	// call made through this Client, so if the same *Client is used from
+	// multiple goroutines, one goroutine's rate-limit state can affect
+	// another's retry timing; give each goroutine its own *Client to avoid
+	// that.
 	RateLimit Rate
 
+	// RetryPolicy controls how Do retries 429/5xx responses.
+	RetryPolicy RetryPolicy
+
+	// Logger, when set, receives a trace of every API call. The
+	// X-Auth-Token and X-Consumer-Token header values are redacted before
+	// being passed to it; see redactHeaders.
+	Logger Logger
+
+	// BeforeRequest, when set, is called with the real, outgoing req just
+	// before it's sent, e.g. to inject a traceparent/B3 header or start a
+	// tracing span. Unlike Logger and AfterResponse, req is not redacted:
+	// mutations BeforeRequest makes (including to req.Header) take effect on
+	// the request actually sent, so it must redact anything sensitive itself
+	// before logging or forwarding it.
+	BeforeRequest func(*http.Request)
+
+	// AfterResponse, when set, is called with the result of every attempt,
+	// e.g. to end a tracing span or emit a metric. err is nil on success.
+	// The *Response passed in is a redacted copy (see redactHeaders); it is
+	// safe to log or forward but mutating it has no effect on the Client.
+	AfterResponse func(*Response, error)
+
 	// Packet Api Objects
 	Plans            PlanService
 	Users            UserService
@@ -87,7 +104,23 @@ type Client struct {
 	OperatingSystems OSService
 }
 
+// NewRequest is a thin wrapper around NewRequestWithContext using
+// context.Background(), kept for callers that don't need cancellation.
 func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, body)
+}
+
+// NewRequestWithContext builds an API request bound to ctx, so callers can
+// cancel it or attach a deadline (e.g. from a Kubernetes operator or CI job).
+//
+// NOTE: this only covers Client itself. The per-resource *ServiceOp types
+// (Plans/Users/Devices/...) and their Get/List/Create/Update/Delete methods
+// are not present in this tree, so ctx-accepting variants of those methods
+// could not be added here and remain outstanding, unimplemented work -- not
+// a stylistic choice. Any service implementation added to this package
+// should take a context.Context as its first parameter and call
+// NewRequestWithContext instead of NewRequest.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
 	// relative path to append to the endpoint url, no leading slash please
 	rel, err := url.Parse(path)
 	if err != nil {
@@ -105,7 +138,7 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -121,10 +154,79 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 	return req, nil
 }
 
+// Do is a thin wrapper around DoWithContext using req's own context (which is
+// context.Background() for requests built by NewRequest).
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+	return c.DoWithContext(req.Context(), req, v)
+}
+
+// DoWithContext binds req to ctx and sends it, retrying per c.RetryPolicy.
+func (c *Client) DoWithContext(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	// req.WithContext returns a shallow copy; skip it when ctx is already
+	// req's context (the common Do path) so BeforeRequest still sees the
+	// caller's actual *http.Request rather than a look-alike copy.
+	if req.Context() != ctx {
+		req = req.WithContext(ctx)
+	}
+	policy := c.RetryPolicy
+
+	var response *Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		response, err = c.do(req, v)
+
+		retry := false
+		if policy.ShouldRetry != nil {
+			retry = policy.ShouldRetry(response, err)
+		} else if response != nil {
+			// CheckResponse always returns a non-nil *ErrorResponse for a
+			// non-2xx status, so the retry decision must key off the status
+			// itself rather than err == nil.
+			retry = policy.isRetryableStatus(response.StatusCode)
+		}
+
+		if !retry || attempt >= policy.MaxRetries || !canRetryRequest(req) {
+			return response, err
+		}
+
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return response, err
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(retryWait(policy, attempt, c.RateLimit))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return response, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// do performs a single attempt of req, without retrying.
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	if c.BeforeRequest != nil {
+		c.BeforeRequest(req)
+	}
+	if c.Logger != nil {
+		c.Logger.Debugf("packngo: %s %s headers=%v", req.Method, req.URL, redactHeaders(req.Header))
+	}
+
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		if c.Logger != nil {
+			c.Logger.Warnf("packngo: %s %s failed: %v", req.Method, req.URL, err)
+		}
+		netErr := newNetworkErrorResponse(err)
+		if c.AfterResponse != nil {
+			c.AfterResponse(nil, netErr)
+		}
+		return nil, netErr
 	}
 
 	defer resp.Body.Close()
@@ -136,6 +238,12 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	err = CheckResponse(resp)
 	// if the response is an error, return the ErrorReponse
 	if err != nil {
+		if c.Logger != nil {
+			c.Logger.Warnf("packngo: %s %s: %v", req.Method, req.URL, err)
+		}
+		if c.AfterResponse != nil {
+			c.AfterResponse(redactedForHook(&response), err)
+		}
 		return &response, err
 	}
 
@@ -146,21 +254,40 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 		} else {
 			err = json.NewDecoder(resp.Body).Decode(v)
 			if err != nil {
+				if c.AfterResponse != nil {
+					c.AfterResponse(redactedForHook(&response), err)
+				}
 				return &response, err
 			}
 		}
 	}
 
+	if c.Logger != nil {
+		c.Logger.Infof("packngo: %s %s -> %d", req.Method, req.URL, response.StatusCode)
+	}
+	if c.AfterResponse != nil {
+		c.AfterResponse(redactedForHook(&response), nil)
+	}
+
 	return &response, err
 }
 
-// initializes and returns a Client, use this to get an API Client to operate on
+// NewClient initializes and returns a Client, use this to get an API Client to operate on.
+// It uses a private *http.Transport verified against the system root CA pool;
+// use NewClientWithConfig to customize TLS verification or supply your own
+// *http.Client.
 func NewClient(consumerToken string, apiKey string) *Client {
-	httpClient := http.DefaultClient
+	return NewClientWithConfig(consumerToken, apiKey, nil)
+}
+
+// NewClientWithConfig initializes and returns a Client using cfg to build the
+// underlying HTTP transport. A nil cfg is equivalent to NewClient.
+func NewClientWithConfig(consumerToken string, apiKey string, cfg *ClientConfig) *Client {
+	httpClient := defaultHTTPClient(cfg)
 
 	BaseURL, _ := url.Parse(baseURL)
 
-	c := &Client{client: httpClient, BaseURL: BaseURL, UserAgent: userAgent, ConsumerToken: consumerToken, ApiKey: apiKey}
+	c := &Client{client: httpClient, BaseURL: BaseURL, UserAgent: userAgent, ConsumerToken: consumerToken, ApiKey: apiKey, RetryPolicy: defaultRetryPolicy}
 	c.Plans = &PlanServiceOp{client: c}
 	c.Users = &UserServiceOp{client: c}
 	c.Emails = &EmailServiceOp{client: c}
@@ -170,30 +297,6 @@ func NewClient(consumerToken string, apiKey string) *Client {
 	c.Facilities = &FacilityServiceOp{client: c}
 	c.OperatingSystems = &OSServiceOp{client: c}
 
-	// THIS IS VERY VERY BAD, WE NEED TO FIX THE CERT ON THE SERVER
-	// RELEVANT ERROR IS:
-	// x509: certificate signed by unknown authority (possibly because of "x509: cannot verify signature: algorithm unimplemented" while trying to verify candidate authority certificate "COMODO RSA Certification Authority")
-	cfg := &tls.Config{ InsecureSkipVerify: true }
-	http.DefaultClient.Transport = &http.Transport{
-    TLSClientConfig: cfg,
-	}
-	// END BAD PART
-
 	return c
 }
 
-func CheckResponse(r *http.Response) error {
-	// return if http status code is within 200 range
-	if c := r.StatusCode; c >= 200 && c <= 299 {
-		return nil
-	}
-
-	errorResponse := &ErrorResponse{Response: r}
-	data, err := ioutil.ReadAll(r.Body)
-	// if the response has a body, populate the message in errorResponse
-	if err == nil && len(data) > 0 {
-		json.Unmarshal(data, errorResponse)
-	}
-
-	return errorResponse
-}
@@ -0,0 +1,99 @@
+package packngo
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how Client.Do retries requests that fail with a
+// rate-limit or server error response.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of attempts after the initial
+	// request. Zero disables retries.
+	MaxRetries int
+
+	// MinWait is the base and MaxWait the cap of the exponential backoff
+	// used between attempts when the response carries no usable
+	// X-RateLimit-Reset. MaxWait also caps a wait taken from that header.
+	MinWait time.Duration
+	MaxWait time.Duration
+
+	// RetryableStatusCodes lists the HTTP status codes that trigger a
+	// retry. Defaults to 429 and the 5xx range when left nil.
+	RetryableStatusCodes []int
+
+	// ShouldRetry, when set, overrides the default retry decision entirely.
+	ShouldRetry func(*Response, error) bool
+}
+
+// defaultRetryPolicy is applied by NewClient and NewClientWithConfig.
+var defaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinWait:    time.Second,
+	MaxWait:    30 * time.Second,
+}
+
+// isRetryableStatus reports whether code should trigger a retry under
+// policy, using the 429/5xx default when RetryableStatusCodes is unset.
+func (policy RetryPolicy) isRetryableStatus(code int) bool {
+	if len(policy.RetryableStatusCodes) == 0 {
+		return code == http.StatusTooManyRequests || code >= 500
+	}
+	for _, c := range policy.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// canRetryRequest reports whether req may be safely resent. A request is
+// retryable only if it has no body or that body can be rewound via
+// GetBody — this applies to every method, including idempotent ones like
+// PUT, since a non-seekable body (e.g. a streaming io.Reader) can't be
+// replayed regardless of method semantics.
+func canRetryRequest(req *http.Request) bool {
+	// A client request with ContentLength 0 and a non-nil Body has a body of
+	// unknown length, not an empty one.
+	return req.GetBody != nil || (req.ContentLength == 0 && (req.Body == nil || req.Body == http.NoBody))
+}
+
+// retryWait computes how long to sleep before the next attempt, preferring
+// the server-supplied rate-limit reset time (capped at policy.MaxWait) and
+// otherwise backing off exponentially with full jitter: a random duration in
+// [0, min(policy.MaxWait, policy.MinWait<<attempt)].
+//
+// Known limitation: callers share one Rate (Client.RateLimit) across every
+// goroutine using the same *Client, so under concurrent use this backoff can
+// be keyed off a different, unrelated call's rate-limit state rather than
+// the request actually being retried -- and a network-level failure (no
+// response at all) leaves the previous Rate in place instead of clearing it.
+// Give each goroutine its own *Client if precise per-request backoff matters.
+func retryWait(policy RetryPolicy, attempt int, rate Rate) time.Duration {
+	minWait, maxWait := policy.MinWait, policy.MaxWait
+	if minWait <= 0 {
+		minWait = defaultRetryPolicy.MinWait
+	}
+	if maxWait <= 0 {
+		maxWait = defaultRetryPolicy.MaxWait
+	}
+
+	if reset := rate.Reset.Time; !reset.IsZero() {
+		if wait := time.Until(reset); wait > 0 {
+			// A malicious or buggy server could send a Reset far in the
+			// future; never block longer than MaxWait on its say-so.
+			if wait > maxWait {
+				wait = maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := minWait << uint(attempt)
+	if wait > maxWait || wait <= 0 {
+		wait = maxWait
+	}
+
+	return time.Duration(rand.Int63n(int64(wait) + 1))
+}
@@ -0,0 +1,92 @@
+package packngo
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCanRetryRequest(t *testing.T) {
+	cases := []struct {
+		name          string
+		method        string
+		contentLength int64
+		hasGetBody    bool
+		want          bool
+	}{
+		{"GET no body", http.MethodGet, 0, false, true},
+		{"PUT empty body", http.MethodPut, 0, false, true},
+		{"PUT rewindable body", http.MethodPut, 3, true, true},
+		{"PUT non-rewindable body", http.MethodPut, 3, false, false},
+		{"DELETE non-rewindable body", http.MethodDelete, 3, false, false},
+		{"POST rewindable body", http.MethodPost, 3, true, true},
+		{"POST non-rewindable body", http.MethodPost, 3, false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &http.Request{Method: tc.method, ContentLength: tc.contentLength}
+			if tc.hasGetBody {
+				req.GetBody = func() (io.ReadCloser, error) { return http.NoBody, nil }
+			}
+			if got := canRetryRequest(req); got != tc.want {
+				t.Fatalf("canRetryRequest(%s, len=%d, hasGetBody=%v) = %v, want %v",
+					tc.method, tc.contentLength, tc.hasGetBody, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	defaultPolicy := RetryPolicy{}
+	if !defaultPolicy.isRetryableStatus(http.StatusTooManyRequests) {
+		t.Error("default policy should retry 429")
+	}
+	if !defaultPolicy.isRetryableStatus(http.StatusInternalServerError) {
+		t.Error("default policy should retry 500")
+	}
+	if defaultPolicy.isRetryableStatus(http.StatusNotFound) {
+		t.Error("default policy should not retry 404")
+	}
+
+	custom := RetryPolicy{RetryableStatusCodes: []int{http.StatusNotFound}}
+	if !custom.isRetryableStatus(http.StatusNotFound) {
+		t.Error("custom policy should retry the status codes it lists")
+	}
+	if custom.isRetryableStatus(http.StatusInternalServerError) {
+		t.Error("custom policy should not retry statuses outside its list")
+	}
+}
+
+func TestRetryWaitUsesRateReset(t *testing.T) {
+	policy := RetryPolicy{MinWait: time.Second, MaxWait: 30 * time.Second}
+	reset := time.Now().Add(5 * time.Second)
+	rate := Rate{Reset: Timestamp{reset}}
+
+	wait := retryWait(policy, 0, rate)
+	if wait <= 0 || wait > 6*time.Second {
+		t.Fatalf("expected wait close to the rate-limit reset, got %v", wait)
+	}
+}
+
+func TestRetryWaitClampsFarFutureReset(t *testing.T) {
+	policy := RetryPolicy{MinWait: time.Second, MaxWait: 30 * time.Second}
+	reset := time.Now().Add(6 * time.Hour)
+	rate := Rate{Reset: Timestamp{reset}}
+
+	if wait := retryWait(policy, 0, rate); wait != policy.MaxWait {
+		t.Fatalf("expected a far-future Reset to be clamped to MaxWait (%v), got %v", policy.MaxWait, wait)
+	}
+}
+
+func TestRetryWaitBackoffBounds(t *testing.T) {
+	policy := RetryPolicy{MinWait: 100 * time.Millisecond, MaxWait: time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := retryWait(policy, attempt, Rate{})
+		if wait < 0 || wait > policy.MaxWait {
+			t.Fatalf("attempt %d: wait %v out of bounds [0, %v]", attempt, wait, policy.MaxWait)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package packngo
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// RevocationConfig controls optional CRL/OCSP revocation checking of the
+// Packet API server's certificate chain, performed after the TLS handshake
+// via tls.Config.VerifyConnection.
+type RevocationConfig struct {
+	// HardFail, when true, aborts the connection if a CRL or OCSP check
+	// cannot be completed (network error, malformed response). When false
+	// (the default), such a failure is reported through Logger (if set)
+	// and the connection proceeds.
+	HardFail bool
+
+	// Logger, when set, receives soft-fail diagnostics: CRL/OCSP errors
+	// that didn't abort the connection because HardFail is false. RevocationConfig
+	// is built before any Client exists, so this is independent of
+	// Client.Logger; share the same Logger value between the two to route
+	// both through one sink. When nil, soft-fail diagnostics are dropped.
+	Logger Logger
+}
+
+type ocspCacheKey struct {
+	issuer string
+	serial string
+}
+
+type ocspCacheEntry struct {
+	response   *ocsp.Response
+	nextUpdate time.Time
+}
+
+var (
+	ocspCacheMu sync.Mutex
+	ocspCache   = map[ocspCacheKey]ocspCacheEntry{}
+)
+
+// revocationCheckTimeout bounds each individual CRL/OCSP HTTP round trip.
+// Without it, a slow or unresponsive responder would hang the TLS handshake
+// indefinitely, regardless of HardFail.
+const revocationCheckTimeout = 10 * time.Second
+
+var revocationHTTPClient = &http.Client{Timeout: revocationCheckTimeout}
+
+// revocationVerifyConnection returns a tls.Config.VerifyConnection callback
+// that checks every certificate in the verified chain against its CRL
+// distribution points and OCSP responder, in the style of cfssl's revCheck.
+func revocationVerifyConnection(cfg *RevocationConfig) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.VerifiedChains) == 0 {
+			return nil
+		}
+
+		chain := cs.VerifiedChains[0]
+		for i, cert := range chain {
+			issuer := cert
+			if i+1 < len(chain) {
+				issuer = chain[i+1]
+			}
+
+			crlRevoked, err := checkCRL(cert)
+			if err != nil {
+				if cfg.HardFail {
+					return err
+				}
+				if cfg.Logger != nil {
+					cfg.Logger.Warnf("packngo: CRL check failed for %s: %v", cert.Subject, err)
+				}
+			}
+			if crlRevoked {
+				return fmt.Errorf("packngo: certificate %s is revoked (CRL)", cert.Subject)
+			}
+
+			revoked, err := checkOCSP(cert, issuer)
+			if err != nil {
+				if cfg.HardFail {
+					return err
+				}
+				if cfg.Logger != nil {
+					cfg.Logger.Warnf("packngo: OCSP check failed for %s: %v", cert.Subject, err)
+				}
+				continue
+			}
+			if revoked {
+				return fmt.Errorf("packngo: certificate %s is revoked (OCSP)", cert.Subject)
+			}
+		}
+
+		return nil
+	}
+}
+
+// checkCRL fetches and parses each of cert's non-LDAP CRL distribution
+// points, reporting whether cert's serial appears in a RevokedCertificates
+// list. A non-nil error means a CRL could not be fetched or parsed.
+func checkCRL(cert *x509.Certificate) (bool, error) {
+	for _, dp := range cert.CRLDistributionPoints {
+		if strings.HasPrefix(dp, "ldap://") {
+			continue
+		}
+
+		resp, err := revocationHTTPClient.Get(dp)
+		if err != nil {
+			return false, err
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return false, err
+		}
+
+		crl, err := x509.ParseCRL(data)
+		if err != nil {
+			return false, err
+		}
+
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// checkOCSP asks cert's OCSP responder(s) whether cert has been revoked,
+// caching the response in memory keyed by issuer+serial until its NextUpdate.
+func checkOCSP(cert, issuer *x509.Certificate) (bool, error) {
+	if len(cert.OCSPServer) == 0 {
+		return false, nil
+	}
+
+	key := ocspCacheKey{issuer: issuer.Subject.String(), serial: cert.SerialNumber.String()}
+
+	ocspCacheMu.Lock()
+	if entry, ok := ocspCache[key]; ok && time.Now().Before(entry.nextUpdate) {
+		ocspCacheMu.Unlock()
+		return entry.response.Status == ocsp.Revoked, nil
+	}
+	ocspCacheMu.Unlock()
+
+	reqBytes, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var lastErr error
+	for _, server := range cert.OCSPServer {
+		resp, err := revocationHTTPClient.Post(server, "application/ocsp-request", bytes.NewReader(reqBytes))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		ocspResp, err := ocsp.ParseResponse(body, issuer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		ocspCacheMu.Lock()
+		ocspCache[key] = ocspCacheEntry{response: ocspResp, nextUpdate: ocspResp.NextUpdate}
+		ocspCacheMu.Unlock()
+
+		return ocspResp.Status == ocsp.Revoked, nil
+	}
+
+	return false, fmt.Errorf("packngo: all OCSP responders for %s failed: %v", cert.Subject, lastErr)
+}
@@ -0,0 +1,75 @@
+package packngo
+
+import (
+	"crypto/x509"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+func testOCSPCacheKey(cert, issuer *x509.Certificate) ocspCacheKey {
+	return ocspCacheKey{issuer: issuer.Subject.String(), serial: cert.SerialNumber.String()}
+}
+
+func TestCheckOCSPUsesUnexpiredCacheEntry(t *testing.T) {
+	cert := mustSelfSignedCert(t, "leaf-cached")
+	cert.SerialNumber = big.NewInt(101)
+	cert.OCSPServer = []string{"http://unreachable.invalid"}
+	issuer := mustSelfSignedCert(t, "issuer-cached")
+
+	key := testOCSPCacheKey(cert, issuer)
+	ocspCacheMu.Lock()
+	ocspCache[key] = ocspCacheEntry{
+		response:   &ocsp.Response{Status: ocsp.Revoked},
+		nextUpdate: time.Now().Add(time.Hour),
+	}
+	ocspCacheMu.Unlock()
+	defer func() {
+		ocspCacheMu.Lock()
+		delete(ocspCache, key)
+		ocspCacheMu.Unlock()
+	}()
+
+	revoked, err := checkOCSP(cert, issuer)
+	if err != nil {
+		t.Fatalf("expected the cached entry to satisfy the check without a network call, got %v", err)
+	}
+	if !revoked {
+		t.Error("expected the cached Revoked status to be honored")
+	}
+}
+
+func TestCheckOCSPRefetchesAfterExpiry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	cert := mustSelfSignedCert(t, "leaf-expired")
+	cert.SerialNumber = big.NewInt(102)
+	cert.OCSPServer = []string{srv.URL}
+	issuer := mustSelfSignedCert(t, "issuer-expired")
+
+	key := testOCSPCacheKey(cert, issuer)
+	ocspCacheMu.Lock()
+	ocspCache[key] = ocspCacheEntry{
+		response:   &ocsp.Response{Status: ocsp.Good},
+		nextUpdate: time.Now().Add(-time.Hour), // already expired
+	}
+	ocspCacheMu.Unlock()
+	defer func() {
+		ocspCacheMu.Lock()
+		delete(ocspCache, key)
+		ocspCacheMu.Unlock()
+	}()
+
+	// An expired entry must not be trusted: checkOCSP should go back out to
+	// the (failing) responder rather than returning the stale Good status.
+	if _, err := checkOCSP(cert, issuer); err == nil {
+		t.Error("expected a fresh lookup to be attempted and fail against the stub responder")
+	}
+}
@@ -0,0 +1,94 @@
+package packngo
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// ClientConfig customizes the HTTP transport used to reach the Packet API.
+// The zero value results in a default, fully-verifying *http.Transport backed
+// by the system root CA pool.
+type ClientConfig struct {
+	// HTTPClient, when set, is used as-is and the fields below are ignored.
+	// Use this when the caller already manages its own transport (proxies,
+	// connection pooling, instrumentation, etc).
+	HTTPClient *http.Client
+
+	// RootCAs, when set, is used instead of the system root pool to verify
+	// the API server's certificate chain.
+	RootCAs *x509.CertPool
+
+	// PinnedSPKIHashes, when non-empty, additionally requires that at least
+	// one certificate in the verified chain have a SHA-256 hash of its
+	// SubjectPublicKeyInfo matching one of these values. This lets callers
+	// in constrained environments pin the Packet API's key without
+	// weakening verification for every other HTTP call in the process.
+	PinnedSPKIHashes [][sha256.Size]byte
+
+	// Revocation, when set, enables CRL/OCSP revocation checking of the API
+	// server's certificate chain after the TLS handshake.
+	Revocation *RevocationConfig
+}
+
+// NewCertPoolFromPEM parses PEM-encoded certificates into an *x509.CertPool
+// suitable for ClientConfig.RootCAs.
+func NewCertPoolFromPEM(pemCerts []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("packngo: no certificates found in PEM input")
+	}
+	return pool, nil
+}
+
+// spkiSHA256 returns the SHA-256 hash of a certificate's SubjectPublicKeyInfo.
+func spkiSHA256(cert *x509.Certificate) [sha256.Size]byte {
+	return sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+}
+
+// verifyPinnedSPKI returns a VerifyPeerCertificate callback that fails the
+// handshake unless one of the verified chains contains a certificate whose
+// SPKI hash matches a pinned value.
+func verifyPinnedSPKI(pins [][sha256.Size]byte) func([][]byte, [][]*x509.Certificate) error {
+	return func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for _, chain := range verifiedChains {
+			for _, cert := range chain {
+				hash := spkiSHA256(cert)
+				for _, pin := range pins {
+					if hash == pin {
+						return nil
+					}
+				}
+			}
+		}
+		return fmt.Errorf("packngo: no certificate in the verified chain matched a pinned SPKI hash")
+	}
+}
+
+// defaultHTTPClient builds the *http.Client used when the caller does not
+// supply one, based on cfg. A nil cfg yields a private *http.Transport with
+// verification enabled against the system root pool.
+func defaultHTTPClient(cfg *ClientConfig) *http.Client {
+	if cfg != nil && cfg.HTTPClient != nil {
+		return cfg.HTTPClient
+	}
+
+	tlsConfig := &tls.Config{}
+	if cfg != nil && cfg.RootCAs != nil {
+		tlsConfig.RootCAs = cfg.RootCAs
+	}
+	if cfg != nil && len(cfg.PinnedSPKIHashes) > 0 {
+		tlsConfig.VerifyPeerCertificate = verifyPinnedSPKI(cfg.PinnedSPKIHashes)
+	}
+	if cfg != nil && cfg.Revocation != nil {
+		tlsConfig.VerifyConnection = revocationVerifyConnection(cfg.Revocation)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package packngo
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func mustSelfSignedCert(t *testing.T, commonName string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestNewCertPoolFromPEM(t *testing.T) {
+	cert := mustSelfSignedCert(t, "packngo-test")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+
+	pool, err := NewCertPoolFromPEM(pemBytes)
+	if err != nil {
+		t.Fatalf("NewCertPoolFromPEM: %v", err)
+	}
+	if pool == nil {
+		t.Fatal("expected a non-nil pool")
+	}
+
+	if _, err := NewCertPoolFromPEM([]byte("not a certificate")); err == nil {
+		t.Fatal("expected an error for input with no certificates")
+	}
+}
+
+func TestVerifyPinnedSPKI(t *testing.T) {
+	cert := mustSelfSignedCert(t, "packngo-test")
+	chain := [][]*x509.Certificate{{cert}}
+	pin := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	verify := verifyPinnedSPKI([][sha256.Size]byte{pin})
+	if err := verify(nil, chain); err != nil {
+		t.Errorf("expected the matching pin to verify, got %v", err)
+	}
+
+	wrongPin := sha256.Sum256([]byte("not the cert's SPKI"))
+	verify = verifyPinnedSPKI([][sha256.Size]byte{wrongPin})
+	if err := verify(nil, chain); err == nil {
+		t.Error("expected a mismatched pin to fail verification")
+	}
+}